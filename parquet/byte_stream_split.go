@@ -0,0 +1,44 @@
+package parquet
+
+import "fmt"
+
+// byteStreamSplitCore reconstructs fixed-width values encoded with
+// BYTE_STREAM_SPLIT. Rather than storing values back to back, the page
+// holds numValues copies of byte 0 of every value, then numValues copies of
+// byte 1, and so on for typeWidth bytes total; value i is byte plane b at
+// offset b*numValues+i.
+type byteStreamSplitCore struct {
+	data      []byte
+	typeWidth int
+	numValues int32
+
+	pos int
+}
+
+func (d *byteStreamSplitCore) init(data []byte, typeWidth int, numValues int32) error {
+	if want := int(numValues) * typeWidth; len(data) != want {
+		return fmt.Errorf("bytestreamsplit: expected %d bytes for %d values of width %d, got %d", want, numValues, typeWidth, len(data))
+	}
+
+	d.data = data
+	d.typeWidth = typeWidth
+	d.numValues = numValues
+	d.pos = 0
+
+	return nil
+}
+
+// next reassembles the value at the current position into scratch, which
+// must be exactly typeWidth bytes, and advances the position.
+func (d *byteStreamSplitCore) next(scratch []byte) error {
+	if d.pos >= int(d.numValues) {
+		return errNED
+	}
+
+	for b := 0; b < d.typeWidth; b++ {
+		scratch[b] = d.data[b*int(d.numValues)+d.pos]
+	}
+	d.pos++
+
+	return nil
+}
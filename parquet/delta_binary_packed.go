@@ -0,0 +1,302 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// Defaults taken from the Parquet reference implementation: 128 values per
+// block split into 4 mini-blocks of 32 values each.
+const (
+	deltaBlockSize     = 128
+	deltaMiniBlocks    = 4
+	deltaMiniBlockSize = deltaBlockSize / deltaMiniBlocks
+)
+
+// packMiniBlock bit-packs up to miniBlockSize deltas (already shifted by
+// minDelta so every value is non-negative) at the given width. A partial
+// trailing mini-block is padded with zero values so the result is always
+// exactly miniBlockSize/8*width bytes, the layout int64DeltaBinaryPackedDecoder
+// and int32DeltaBinaryPackedDecoder assume when skipping a final mini-block.
+func packMiniBlock(deltas []int64, minDelta int64, width, miniBlockSize int) []byte {
+	out := make([]byte, miniBlockSize/8*width)
+
+	bitPos := 0
+	for i := 0; i < miniBlockSize; i++ {
+		var v uint64
+		if i < len(deltas) {
+			v = uint64(deltas[i] - minDelta)
+		}
+		for b := 0; b < width; b++ {
+			if v&(1<<uint(b)) != 0 {
+				out[bitPos/8] |= 1 << uint(bitPos%8)
+			}
+			bitPos++
+		}
+	}
+
+	return out
+}
+
+// miniBlockWidth returns the number of bits needed to hold the largest of the
+// given (already minDelta-shifted) deltas.
+func miniBlockWidth(deltas []int64, minDelta int64) int {
+	var max uint64
+	for _, d := range deltas {
+		v := uint64(d - minDelta)
+		if v > max {
+			max = v
+		}
+	}
+	return bits.Len64(max)
+}
+
+// minInt64 returns the smallest value in vs. vs must not be empty.
+func minInt64(vs []int64) int64 {
+	m := vs[0]
+	for _, v := range vs[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+// encodeDeltaBlocks appends one or more DELTA_BINARY_PACKED blocks encoding
+// deltas, shared by int64DeltaBinaryPackedEncoder and
+// int32DeltaBinaryPackedEncoder. A page always contains at least one block,
+// even when there are zero deltas (a single-value page): readBlockHeader
+// unconditionally reads a block right after the page header, so an encoder
+// that skipped the block for a single-value page would write pages its own
+// decoder cannot read back.
+func encodeDeltaBlocks(buf []byte, deltas []int64, blockSize, miniBlocks, miniBlockSize int32) []byte {
+	if len(deltas) == 0 {
+		return encodeDeltaBlock(buf, nil, miniBlocks, miniBlockSize)
+	}
+
+	for start := 0; start < len(deltas); start += int(blockSize) {
+		end := start + int(blockSize)
+		if end > len(deltas) {
+			end = len(deltas)
+		}
+		buf = encodeDeltaBlock(buf, deltas[start:end], miniBlocks, miniBlockSize)
+	}
+
+	return buf
+}
+
+// block := <min delta> <list of bitwidths of miniblocks> <miniblocks>
+func encodeDeltaBlock(buf []byte, block []int64, miniBlocks, miniBlockSize int32) []byte {
+	var minDelta int64
+	if len(block) > 0 {
+		minDelta = minInt64(block)
+	}
+	buf = appendZigZagVarInt64(buf, minDelta)
+
+	widths := make([]byte, miniBlocks)
+	packed := make([][]byte, miniBlocks)
+	for m := 0; m < int(miniBlocks); m++ {
+		start := m * int(miniBlockSize)
+		if start >= len(block) {
+			continue
+		}
+		end := start + int(miniBlockSize)
+		if end > len(block) {
+			end = len(block)
+		}
+
+		width := miniBlockWidth(block[start:end], minDelta)
+		widths[m] = byte(width)
+		packed[m] = packMiniBlock(block[start:end], minDelta, width, int(miniBlockSize))
+	}
+
+	buf = append(buf, widths...)
+	for _, p := range packed {
+		buf = append(buf, p...)
+	}
+
+	return buf
+}
+
+// deltaBinaryPackedCore implements the DELTA_BINARY_PACKED block/mini-block
+// state machine shared by int64DeltaBinaryPackedDecoder and
+// int32DeltaBinaryPackedDecoder. maxWidth bounds the mini-block bit widths a
+// decoder can legally see (64 for int64, 32 for int32) and must be set by
+// the embedding type before init/initReader is called.
+//
+// The state machine itself only talks to the page through src, a
+// deltaSource, so the exact same header-parsing and mini-block loop serves
+// both the in-memory init(data []byte) entry point and the streaming
+// initReader(r io.Reader, size int) one.
+type deltaBinaryPackedCore struct {
+	src      deltaSource
+	maxWidth int
+
+	blockSize     int32
+	miniBlocks    int32
+	miniBlockSize int32
+	numValues     int32
+
+	minDelta        int64
+	miniBlockWidths []byte
+
+	i               int
+	value           int64
+	miniBlock       int
+	miniBlockWidth  int
+	unpacker        unpack8int64Func
+	miniBlockPos    int
+	miniBlockValues [8]int64
+}
+
+func (d *deltaBinaryPackedCore) init(data []byte) error {
+	return d.initSource(&sliceDeltaSource{data: data})
+}
+
+func (d *deltaBinaryPackedCore) initReader(r io.Reader, size int) error {
+	return d.initSource(&readerDeltaSource{r: newTrackingByteReader(r, size)})
+}
+
+func (d *deltaBinaryPackedCore) initSource(src deltaSource) error {
+	d.src = src
+	d.i = 0
+
+	if err := d.readPageHeader(); err != nil {
+		return err
+	}
+	if err := d.readBlockHeader(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// pos reports how many page bytes have been consumed so far. It is only
+// meaningful for decoders initialized via init (the in-memory slice path);
+// callers that stream via initReader have no byte offset to hand back.
+func (d *deltaBinaryPackedCore) pos() int {
+	s, ok := d.src.(*sliceDeltaSource)
+	if !ok {
+		return 0
+	}
+	return s.pos
+}
+
+// page-header := <block size in values> <number of miniblocks in a block> <total value count> <first value>
+func (d *deltaBinaryPackedCore) readPageHeader() error {
+	var err error
+
+	d.blockSize, err = d.src.readVarInt32()
+	if err != nil {
+		return fmt.Errorf("delta: failed to read block size: %v", err)
+	}
+
+	d.miniBlocks, err = d.src.readVarInt32()
+	if err != nil {
+		return fmt.Errorf("delta: failed to read number of mini blocks: %v", err)
+	}
+	// TODO: valdiate d.miniBlocks
+	d.miniBlockWidths = make([]byte, d.miniBlocks, d.miniBlocks)
+
+	d.miniBlockSize = d.blockSize / d.miniBlocks // TODO: rounding
+
+	d.numValues, err = d.src.readVarInt32()
+	if err != nil {
+		return fmt.Errorf("delta: failed to read total value count: %v", err)
+	}
+
+	d.value, err = d.src.readZigZagVarInt64()
+	if err != nil {
+		return fmt.Errorf("delta: failed to read first value: %v", err)
+	}
+
+	return nil
+}
+
+// block := <min delta> <list of bitwidths of miniblocks> <miniblocks>
+// min delta : zig-zag var int encoded
+// bitWidthsOfMiniBlock : 1 byte little endian
+func (d *deltaBinaryPackedCore) readBlockHeader() error {
+	var err error
+
+	d.minDelta, err = d.src.readZigZagVarInt64()
+	if err != nil {
+		return fmt.Errorf("delta: failed to read min delta: %v", err)
+	}
+
+	widths, err := d.src.readBytes(len(d.miniBlockWidths))
+	if err != nil {
+		return fmt.Errorf("delta: failed to read all bitwidths of miniblocks: %v", err)
+	}
+	copy(d.miniBlockWidths, widths)
+
+	for _, w := range d.miniBlockWidths {
+		if int(w) > d.maxWidth {
+			return fmt.Errorf("delta: mini-block bit width %d exceeds maximum of %d", w, d.maxWidth)
+		}
+	}
+
+	d.miniBlock = 0
+
+	return nil
+}
+
+func (d *deltaBinaryPackedCore) decodeInt64(dst []int64) error {
+	n := 0
+	for n < len(dst) && d.i < int(d.numValues) {
+		// The last value never consumes a delta, so it must not trigger a
+		// group fetch: when the delta count lands exactly on a mini-block or
+		// block boundary, the group past it was never written.
+		if d.i%8 == 0 && d.i < int(d.numValues)-1 {
+			if err := d.fillNextGroup(); err != nil {
+				return err
+			}
+		}
+		dst[n] = d.value
+		d.value += d.miniBlockValues[d.i%8] + d.minDelta
+		d.i++
+		n++
+	}
+	if n == 0 {
+		return fmt.Errorf("delta: no more data")
+	}
+
+	return nil
+}
+
+// fillNextGroup advances the state machine to the next group of 8 packed
+// values, reading a new block header or mini-block width whenever the
+// current position crosses one of those boundaries, and discarding the
+// padding bytes of a partial trailing mini-block once the last group of 8
+// needed to satisfy numValues has been read.
+func (d *deltaBinaryPackedCore) fillNextGroup() error {
+	if d.i%int(d.miniBlockSize) == 0 {
+		if d.miniBlock >= int(d.miniBlocks) {
+			if err := d.readBlockHeader(); err != nil {
+				return err
+			}
+		}
+
+		d.miniBlockWidth = int(d.miniBlockWidths[d.miniBlock])
+		d.unpacker = unpack8Int64FuncByWidth[d.miniBlockWidth]
+		d.miniBlockPos = 0
+		d.miniBlock++
+	}
+
+	w := int(d.miniBlockWidth)
+	group, err := d.src.readBytes(w)
+	if err != nil {
+		return fmt.Errorf("delta: not enough data: %v", err)
+	}
+	d.miniBlockValues = d.unpacker(group)
+	d.miniBlockPos += w
+
+	if d.i+8 >= int(d.numValues)-1 {
+		if err := d.src.skip(int(d.miniBlockSize)/8*w - d.miniBlockPos); err != nil {
+			return fmt.Errorf("delta: not enough data: %v", err)
+		}
+	}
+
+	return nil
+}
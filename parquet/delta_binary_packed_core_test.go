@@ -0,0 +1,33 @@
+package parquet
+
+import "testing"
+
+func TestInt32DeltaBinaryPackedRejectsOversizedWidth(t *testing.T) {
+	var page []byte
+	page = appendVarInt32(page, 8)       // block size
+	page = appendVarInt32(page, 1)       // mini blocks
+	page = appendVarInt32(page, 8)       // total value count
+	page = appendZigZagVarInt64(page, 0) // first value
+	page = appendZigZagVarInt64(page, 0) // min delta
+	page = append(page, 33)              // mini-block width: valid for int64, too wide for int32
+
+	var dec int32DeltaBinaryPackedDecoder
+	if err := dec.init(page); err == nil {
+		t.Fatalf("expected an error for a mini-block width exceeding 32 bits, got nil")
+	}
+}
+
+func TestInt64DeltaBinaryPackedAcceptsWideWidth(t *testing.T) {
+	var page []byte
+	page = appendVarInt32(page, 8)       // block size
+	page = appendVarInt32(page, 1)       // mini blocks
+	page = appendVarInt32(page, 8)       // total value count
+	page = appendZigZagVarInt64(page, 0) // first value
+	page = appendZigZagVarInt64(page, 0) // min delta
+	page = append(page, 33)              // mini-block width: invalid for int32, valid for int64
+
+	var dec int64DeltaBinaryPackedDecoder
+	if err := dec.init(page); err != nil {
+		t.Fatalf("unexpected error for a 33-bit mini-block width on an int64 decoder: %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package parquet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func deltaRoundTripSizes() []int {
+	return []int{
+		1, 2,
+		deltaMiniBlockSize - 1, deltaMiniBlockSize, deltaMiniBlockSize + 1,
+		deltaBlockSize - 1, deltaBlockSize, deltaBlockSize + 1,
+		deltaBlockSize*2 + 5,
+	}
+}
+
+func TestInt64DeltaBinaryPackedRoundTrip(t *testing.T) {
+	for _, n := range deltaRoundTripSizes() {
+		values := make([]int64, n)
+		for i := range values {
+			values[i] = int64(i)*7 - 3
+		}
+
+		enc := newInt64DeltaBinaryPackedEncoder()
+		data, err := enc.encodeInt64(values)
+		if err != nil {
+			t.Fatalf("size %d: encode: %v", n, err)
+		}
+
+		var dec int64DeltaBinaryPackedDecoder
+		if err := dec.init(data); err != nil {
+			t.Fatalf("size %d: init: %v", n, err)
+		}
+
+		got := make([]int64, n)
+		if err := dec.decodeInt64(got); err != nil {
+			t.Fatalf("size %d: decode: %v", n, err)
+		}
+
+		if !reflect.DeepEqual(got, values) {
+			t.Fatalf("size %d: got %v, want %v", n, got, values)
+		}
+	}
+}
+
+func TestInt32DeltaBinaryPackedRoundTrip(t *testing.T) {
+	for _, n := range deltaRoundTripSizes() {
+		values := make([]int32, n)
+		for i := range values {
+			values[i] = int32(i)*7 - 3
+		}
+
+		enc := newInt32DeltaBinaryPackedEncoder()
+		data, err := enc.encodeInt32(values)
+		if err != nil {
+			t.Fatalf("size %d: encode: %v", n, err)
+		}
+
+		var dec int32DeltaBinaryPackedDecoder
+		if err := dec.init(data); err != nil {
+			t.Fatalf("size %d: init: %v", n, err)
+		}
+
+		got := make([]int32, n)
+		if err := dec.decodeInt32(got); err != nil {
+			t.Fatalf("size %d: decode: %v", n, err)
+		}
+
+		if !reflect.DeepEqual(got, values) {
+			t.Fatalf("size %d: got %v, want %v", n, got, values)
+		}
+	}
+}
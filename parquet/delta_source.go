@@ -0,0 +1,131 @@
+package parquet
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// deltaSource abstracts where a DELTA_BINARY_PACKED page's bytes come from,
+// so deltaBinaryPackedCore's header-parsing and mini-block loop can run
+// unchanged whether the whole page is already in memory (sliceDeltaSource)
+// or being streamed from a small buffered io.Reader (readerDeltaSource).
+type deltaSource interface {
+	readVarInt32() (int32, error)
+	readZigZagVarInt64() (int64, error)
+	// readBytes returns exactly n bytes. The returned slice is only valid
+	// until the next call into the source.
+	readBytes(n int) ([]byte, error)
+	// skip discards n bytes without returning them.
+	skip(n int) error
+}
+
+// sliceDeltaSource reads a DELTA_BINARY_PACKED page that is already fully
+// loaded in memory, the original behaviour of this decoder.
+type sliceDeltaSource struct {
+	data []byte
+	pos  int
+}
+
+func (s *sliceDeltaSource) readVarInt32() (int32, error) {
+	v, n := varInt32(s.data[s.pos:])
+	if n <= 0 {
+		return 0, fmt.Errorf("not enough data to read a varint")
+	}
+	s.pos += n
+	return v, nil
+}
+
+func (s *sliceDeltaSource) readZigZagVarInt64() (int64, error) {
+	v, n := zigZagVarInt64(s.data[s.pos:])
+	if n <= 0 {
+		return 0, fmt.Errorf("not enough data to read a zig-zag varint")
+	}
+	s.pos += n
+	return v, nil
+}
+
+func (s *sliceDeltaSource) readBytes(n int) ([]byte, error) {
+	if s.pos+n > len(s.data) {
+		return nil, fmt.Errorf("not enough data")
+	}
+	b := s.data[s.pos : s.pos+n]
+	s.pos += n
+	return b, nil
+}
+
+func (s *sliceDeltaSource) skip(n int) error {
+	if s.pos+n > len(s.data) {
+		return fmt.Errorf("not enough data")
+	}
+	s.pos += n
+	return nil
+}
+
+// trackingByteReader wraps a small buffered reader around an io.Reader,
+// bounding reads to size bytes and satisfying io.ByteReader so varints can
+// be read one byte at a time without buffering the whole page.
+type trackingByteReader struct {
+	r    *bufio.Reader
+	n    int
+	size int
+}
+
+func newTrackingByteReader(r io.Reader, size int) *trackingByteReader {
+	return &trackingByteReader{r: bufio.NewReaderSize(io.LimitReader(r, int64(size)), 4096), size: size}
+}
+
+func (t *trackingByteReader) ReadByte() (byte, error) {
+	if t.n >= t.size {
+		return 0, io.EOF
+	}
+	b, err := t.r.ReadByte()
+	if err == nil {
+		t.n++
+	}
+	return b, err
+}
+
+func (t *trackingByteReader) Read(p []byte) (int, error) {
+	if t.n >= t.size {
+		return 0, io.EOF
+	}
+	if remaining := t.size - t.n; len(p) > remaining {
+		p = p[:remaining]
+	}
+	n, err := t.r.Read(p)
+	t.n += n
+	return n, err
+}
+
+// readerDeltaSource streams a DELTA_BINARY_PACKED page from an io.Reader,
+// holding only the current mini-block's bytes in scratch rather than the
+// whole page.
+type readerDeltaSource struct {
+	r       *trackingByteReader
+	scratch []byte
+}
+
+func (s *readerDeltaSource) readVarInt32() (int32, error) {
+	return readVarInt32(s.r)
+}
+
+func (s *readerDeltaSource) readZigZagVarInt64() (int64, error) {
+	return readZigZagVarInt64(s.r)
+}
+
+func (s *readerDeltaSource) readBytes(n int) ([]byte, error) {
+	if cap(s.scratch) < n {
+		s.scratch = make([]byte, n)
+	}
+	b := s.scratch[:n]
+	if _, err := io.ReadFull(s.r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (s *readerDeltaSource) skip(n int) error {
+	_, err := io.CopyN(io.Discard, s.r, int64(n))
+	return err
+}
@@ -0,0 +1,33 @@
+package parquet
+
+import "fmt"
+
+// Encoding identifies a Parquet column encoding on the write path. It only
+// lists the encodings this package currently has an Encoder for; the full
+// thrift-defined enum lives with the rest of the page/column metadata
+// handling.
+type Encoding int
+
+const (
+	// EncodingDeltaBinaryPacked selects int64/int32DeltaBinaryPackedEncoder.
+	EncodingDeltaBinaryPacked Encoding = iota
+)
+
+// newEncoder is the selection point a writer calls into when it has decided
+// which encoding to use for a column page: it returns the Encoder that
+// writes values of the given bit width (32 or 64) in that encoding.
+func newEncoder(encoding Encoding, bitWidth int) (Encoder, error) {
+	switch encoding {
+	case EncodingDeltaBinaryPacked:
+		switch bitWidth {
+		case 32:
+			return newInt32DeltaBinaryPackedEncoder(), nil
+		case 64:
+			return newInt64DeltaBinaryPackedEncoder(), nil
+		default:
+			return nil, fmt.Errorf("parquet: delta binary packed encoding does not support bit width %d", bitWidth)
+		}
+	default:
+		return nil, fmt.Errorf("parquet: unsupported encoding %d", encoding)
+	}
+}
@@ -0,0 +1,152 @@
+package parquet
+
+import "fmt"
+
+type byteArrayDecoder interface {
+	decodeByteArray(dst [][]byte) error
+}
+
+func decodeByteArray(d byteArrayDecoder, dst interface{}) error {
+	switch dst := dst.(type) {
+	case [][]byte:
+		return d.decodeByteArray(dst)
+	case []interface{}:
+		b := make([][]byte, len(dst), len(dst))
+		err := d.decodeByteArray(b)
+		for i := 0; i < len(dst); i++ {
+			dst[i] = b[i]
+		}
+		return err
+	default:
+		panic("invalid argument")
+	}
+}
+
+// byteArrayDeltaLengthDecoder reads the DELTA_LENGTH_BYTE_ARRAY encoding: a
+// DELTA_BINARY_PACKED int32 stream of lengths, followed by the raw bytes of
+// every value concatenated back to back.
+type byteArrayDeltaLengthDecoder struct {
+	lengths []int32
+	data    []byte
+
+	pos int
+	i   int
+}
+
+func (d *byteArrayDeltaLengthDecoder) init(data []byte) error {
+	var lengthDecoder int32DeltaBinaryPackedDecoder
+	if err := lengthDecoder.init(data); err != nil {
+		return err
+	}
+
+	lengths := make([]int32, lengthDecoder.numValues, lengthDecoder.numValues)
+	if err := lengthDecoder.decodeInt32(lengths); err != nil {
+		return err
+	}
+
+	d.lengths = lengths
+	d.data = data
+	d.pos = lengthDecoder.pos()
+	d.i = 0
+
+	return nil
+}
+
+func (d *byteArrayDeltaLengthDecoder) decode(dst interface{}) error {
+	return decodeByteArray(d, dst)
+}
+
+func (d *byteArrayDeltaLengthDecoder) decodeByteArray(dst [][]byte) error {
+	for n := 0; n < len(dst); n++ {
+		if d.i >= len(d.lengths) {
+			return errNED
+		}
+
+		l := int(d.lengths[d.i])
+		if l < 0 || d.pos+l > len(d.data) {
+			return fmt.Errorf("bytearray/delta-length: not enough data")
+		}
+
+		dst[n] = d.data[d.pos : d.pos+l]
+		d.pos += l
+		d.i++
+	}
+
+	return nil
+}
+
+// byteArrayDeltaDecoder reads the DELTA_BYTE_ARRAY ("incremental") encoding:
+// a DELTA_BINARY_PACKED int32 stream of prefix lengths, a second one of
+// suffix lengths, then the raw suffix bytes of every value concatenated back
+// to back. Each value is reconstructed as prev[:prefixLen] + suffix.
+type byteArrayDeltaDecoder struct {
+	prefixLengths []int32
+	suffixLengths []int32
+	data          []byte
+
+	pos  int
+	i    int
+	prev []byte
+}
+
+func (d *byteArrayDeltaDecoder) init(data []byte) error {
+	var prefixDecoder int32DeltaBinaryPackedDecoder
+	if err := prefixDecoder.init(data); err != nil {
+		return err
+	}
+	prefixLengths := make([]int32, prefixDecoder.numValues, prefixDecoder.numValues)
+	if err := prefixDecoder.decodeInt32(prefixLengths); err != nil {
+		return err
+	}
+
+	var suffixDecoder int32DeltaBinaryPackedDecoder
+	if err := suffixDecoder.init(data[prefixDecoder.pos():]); err != nil {
+		return err
+	}
+	suffixLengths := make([]int32, suffixDecoder.numValues, suffixDecoder.numValues)
+	if err := suffixDecoder.decodeInt32(suffixLengths); err != nil {
+		return err
+	}
+
+	if len(prefixLengths) != len(suffixLengths) {
+		return fmt.Errorf("bytearray/delta: prefix and suffix length streams disagree on value count")
+	}
+
+	d.prefixLengths = prefixLengths
+	d.suffixLengths = suffixLengths
+	d.data = data
+	d.pos = prefixDecoder.pos() + suffixDecoder.pos()
+	d.i = 0
+	d.prev = nil
+
+	return nil
+}
+
+func (d *byteArrayDeltaDecoder) decode(dst interface{}) error {
+	return decodeByteArray(d, dst)
+}
+
+func (d *byteArrayDeltaDecoder) decodeByteArray(dst [][]byte) error {
+	for n := 0; n < len(dst); n++ {
+		if d.i >= len(d.prefixLengths) {
+			return errNED
+		}
+
+		prefixLen := int(d.prefixLengths[d.i])
+		suffixLen := int(d.suffixLengths[d.i])
+		if prefixLen < 0 || prefixLen > len(d.prev) || suffixLen < 0 || d.pos+suffixLen > len(d.data) {
+			return fmt.Errorf("bytearray/delta: not enough data")
+		}
+
+		value := make([]byte, prefixLen+suffixLen)
+		copy(value, d.prev[:prefixLen])
+		copy(value[prefixLen:], d.data[d.pos:d.pos+suffixLen])
+
+		dst[n] = value
+		d.pos += suffixLen
+		d.prev = value
+		d.i++
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package parquet
+
+import (
+	"reflect"
+	"testing"
+)
+
+// encodeInt32LengthStream is a small test helper: it builds a standalone
+// DELTA_BINARY_PACKED int32 page, the wire format byteArrayDeltaLengthDecoder
+// and byteArrayDeltaDecoder expect for their length streams.
+func encodeInt32LengthStream(t *testing.T, values []int32) []byte {
+	t.Helper()
+	enc := newInt32DeltaBinaryPackedEncoder()
+	data, err := enc.encodeInt32(values)
+	if err != nil {
+		t.Fatalf("encodeInt32: %v", err)
+	}
+	return data
+}
+
+func TestByteArrayDeltaLengthDecoderRoundTrip(t *testing.T) {
+	values := [][]byte{
+		[]byte("a"),
+		[]byte("bb"),
+		[]byte(""),
+		[]byte("delta length byte array"),
+	}
+
+	lengths := make([]int32, len(values))
+	var raw []byte
+	for i, v := range values {
+		lengths[i] = int32(len(v))
+		raw = append(raw, v...)
+	}
+
+	page := append(encodeInt32LengthStream(t, lengths), raw...)
+
+	var dec byteArrayDeltaLengthDecoder
+	if err := dec.init(page); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	got := make([][]byte, len(values))
+	if err := dec.decodeByteArray(got); err != nil {
+		t.Fatalf("decodeByteArray: %v", err)
+	}
+
+	for i := range values {
+		if !reflect.DeepEqual(got[i], values[i]) {
+			t.Fatalf("value %d: got %q, want %q", i, got[i], values[i])
+		}
+	}
+}
+
+func TestByteArrayDeltaDecoderRoundTrip(t *testing.T) {
+	values := []string{"parquet", "parking", "part", "party", ""}
+
+	prefixLengths := make([]int32, len(values))
+	suffixLengths := make([]int32, len(values))
+	var suffixes []byte
+	prev := ""
+	for i, v := range values {
+		p := 0
+		for p < len(prev) && p < len(v) && prev[p] == v[p] {
+			p++
+		}
+		prefixLengths[i] = int32(p)
+		suffixLengths[i] = int32(len(v) - p)
+		suffixes = append(suffixes, v[p:]...)
+		prev = v
+	}
+
+	page := encodeInt32LengthStream(t, prefixLengths)
+	page = append(page, encodeInt32LengthStream(t, suffixLengths)...)
+	page = append(page, suffixes...)
+
+	var dec byteArrayDeltaDecoder
+	if err := dec.init(page); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	got := make([][]byte, len(values))
+	if err := dec.decodeByteArray(got); err != nil {
+		t.Fatalf("decodeByteArray: %v", err)
+	}
+
+	for i, v := range values {
+		if string(got[i]) != v {
+			t.Fatalf("value %d: got %q, want %q", i, got[i], v)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+type float32Decoder interface {
+	decodeFloat32(dst []float32) error
+}
+
+func decodeFloat32(d float32Decoder, dst interface{}) error {
+	switch dst := dst.(type) {
+	case []float32:
+		return d.decodeFloat32(dst)
+	case []interface{}:
+		b := make([]float32, len(dst), len(dst))
+		err := d.decodeFloat32(b)
+		for i := 0; i < len(dst); i++ {
+			dst[i] = b[i]
+		}
+		return err
+	default:
+		panic("invalid argument")
+	}
+}
+
+// float32ByteStreamSplitDecoder reads the BYTE_STREAM_SPLIT encoding for
+// FLOAT columns.
+type float32ByteStreamSplitDecoder struct {
+	byteStreamSplitCore
+}
+
+func (d *float32ByteStreamSplitDecoder) init(data []byte, numValues int32) error {
+	return d.byteStreamSplitCore.init(data, 4, numValues)
+}
+
+func (d *float32ByteStreamSplitDecoder) decode(dst interface{}) error {
+	return decodeFloat32(d, dst)
+}
+
+func (d *float32ByteStreamSplitDecoder) decodeFloat32(dst []float32) error {
+	var scratch [4]byte
+	for i := range dst {
+		if err := d.next(scratch[:]); err != nil {
+			return err
+		}
+		dst[i] = math.Float32frombits(binary.LittleEndian.Uint32(scratch[:]))
+	}
+	return nil
+}
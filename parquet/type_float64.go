@@ -0,0 +1,51 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+type float64Decoder interface {
+	decodeFloat64(dst []float64) error
+}
+
+func decodeFloat64(d float64Decoder, dst interface{}) error {
+	switch dst := dst.(type) {
+	case []float64:
+		return d.decodeFloat64(dst)
+	case []interface{}:
+		b := make([]float64, len(dst), len(dst))
+		err := d.decodeFloat64(b)
+		for i := 0; i < len(dst); i++ {
+			dst[i] = b[i]
+		}
+		return err
+	default:
+		panic("invalid argument")
+	}
+}
+
+// float64ByteStreamSplitDecoder reads the BYTE_STREAM_SPLIT encoding for
+// DOUBLE columns.
+type float64ByteStreamSplitDecoder struct {
+	byteStreamSplitCore
+}
+
+func (d *float64ByteStreamSplitDecoder) init(data []byte, numValues int32) error {
+	return d.byteStreamSplitCore.init(data, 8, numValues)
+}
+
+func (d *float64ByteStreamSplitDecoder) decode(dst interface{}) error {
+	return decodeFloat64(d, dst)
+}
+
+func (d *float64ByteStreamSplitDecoder) decodeFloat64(dst []float64) error {
+	var scratch [8]byte
+	for i := range dst {
+		if err := d.next(scratch[:]); err != nil {
+			return err
+		}
+		dst[i] = math.Float64frombits(binary.LittleEndian.Uint64(scratch[:]))
+	}
+	return nil
+}
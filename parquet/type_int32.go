@@ -0,0 +1,147 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+type int32Decoder interface {
+	decodeInt32(dst []int32) error
+}
+
+func decodeInt32(d int32Decoder, dst interface{}) error {
+	switch dst := dst.(type) {
+	case []int32:
+		return d.decodeInt32(dst)
+	case []interface{}:
+		b := make([]int32, len(dst), len(dst))
+		err := d.decodeInt32(b)
+		for i := 0; i < len(dst); i++ {
+			dst[i] = b[i]
+		}
+		return err
+	default:
+		panic("invalid argument")
+	}
+}
+
+// int32DeltaBinaryPackedDecoder reads the DELTA_BINARY_PACKED encoding for
+// INT32 columns. It shares its block/mini-block state machine with
+// int64DeltaBinaryPackedDecoder through deltaBinaryPackedCore, only
+// narrowing the decoded int64 values to int32 and capping mini-block widths
+// at 32 bits.
+type int32DeltaBinaryPackedDecoder struct {
+	deltaBinaryPackedCore
+}
+
+func (d *int32DeltaBinaryPackedDecoder) init(data []byte) error {
+	d.maxWidth = 32
+	return d.deltaBinaryPackedCore.init(data)
+}
+
+// initReader is the streaming counterpart of init: it consumes the page
+// from r instead of requiring the whole (decompressed) page in memory,
+// reading at most size bytes.
+func (d *int32DeltaBinaryPackedDecoder) initReader(r io.Reader, size int) error {
+	d.maxWidth = 32
+	return d.deltaBinaryPackedCore.initReader(r, size)
+}
+
+func (d *int32DeltaBinaryPackedDecoder) decode(dst interface{}) error {
+	return decodeInt32(d, dst)
+}
+
+func (d *int32DeltaBinaryPackedDecoder) decodeInt32(dst []int32) error {
+	tmp := make([]int64, len(dst), len(dst))
+	if err := d.deltaBinaryPackedCore.decodeInt64(tmp); err != nil {
+		return err
+	}
+	for i, v := range tmp {
+		dst[i] = int32(v)
+	}
+	return nil
+}
+
+type int32Encoder interface {
+	encodeInt32(values []int32) ([]byte, error)
+}
+
+func encodeInt32(e int32Encoder, src interface{}) ([]byte, error) {
+	switch src := src.(type) {
+	case []int32:
+		return e.encodeInt32(src)
+	case []interface{}:
+		b := make([]int32, len(src), len(src))
+		for i := range src {
+			b[i] = src[i].(int32)
+		}
+		return e.encodeInt32(b)
+	default:
+		panic("invalid argument")
+	}
+}
+
+// int32DeltaBinaryPackedEncoder is the int32 counterpart of
+// int64DeltaBinaryPackedEncoder; it writes the same page layout with deltas
+// and minDelta computed in 32-bit arithmetic.
+type int32DeltaBinaryPackedEncoder struct {
+	blockSize     int32
+	miniBlocks    int32
+	miniBlockSize int32
+}
+
+func newInt32DeltaBinaryPackedEncoder() *int32DeltaBinaryPackedEncoder {
+	return &int32DeltaBinaryPackedEncoder{
+		blockSize:     deltaBlockSize,
+		miniBlocks:    deltaMiniBlocks,
+		miniBlockSize: deltaMiniBlockSize,
+	}
+}
+
+func (e *int32DeltaBinaryPackedEncoder) encode(src interface{}) ([]byte, error) {
+	return encodeInt32(e, src)
+}
+
+func (e *int32DeltaBinaryPackedEncoder) encodeInt32(values []int32) ([]byte, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("int32/delta: no values to encode")
+	}
+
+	buf := appendVarInt32(nil, e.blockSize)
+	buf = appendVarInt32(buf, e.miniBlocks)
+	buf = appendVarInt32(buf, int32(len(values)))
+	buf = appendZigZagVarInt64(buf, int64(values[0]))
+
+	deltas := make([]int64, len(values)-1)
+	for i := 1; i < len(values); i++ {
+		deltas[i-1] = int64(values[i] - values[i-1])
+	}
+
+	return encodeDeltaBlocks(buf, deltas, e.blockSize, e.miniBlocks, e.miniBlockSize), nil
+}
+
+// int32ByteStreamSplitDecoder reads the BYTE_STREAM_SPLIT encoding for
+// INT32 columns.
+type int32ByteStreamSplitDecoder struct {
+	byteStreamSplitCore
+}
+
+func (d *int32ByteStreamSplitDecoder) init(data []byte, numValues int32) error {
+	return d.byteStreamSplitCore.init(data, 4, numValues)
+}
+
+func (d *int32ByteStreamSplitDecoder) decode(dst interface{}) error {
+	return decodeInt32(d, dst)
+}
+
+func (d *int32ByteStreamSplitDecoder) decodeInt32(dst []int32) error {
+	var scratch [4]byte
+	for i := range dst {
+		if err := d.next(scratch[:]); err != nil {
+			return err
+		}
+		dst[i] = int32(binary.LittleEndian.Uint32(scratch[:]))
+	}
+	return nil
+}
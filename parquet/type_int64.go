@@ -4,12 +4,38 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 )
 
+// Encoder is the write-side counterpart of the decoder types below: it turns
+// a slice of values into the on-disk bytes for a single Parquet encoding.
+type Encoder interface {
+	encode(src interface{}) ([]byte, error)
+}
+
 type int64Decoder interface {
 	decodeInt64(dst []int64) error
 }
 
+type int64Encoder interface {
+	encodeInt64(values []int64) ([]byte, error)
+}
+
+func encodeInt64(e int64Encoder, src interface{}) ([]byte, error) {
+	switch src := src.(type) {
+	case []int64:
+		return e.encodeInt64(src)
+	case []interface{}:
+		b := make([]int64, len(src), len(src))
+		for i := range src {
+			b[i] = src[i].(int64)
+		}
+		return e.encodeInt64(b)
+	default:
+		panic("invalid argument")
+	}
+}
+
 func decodeInt64(d int64Decoder, dst interface{}) error {
 	switch dst := dst.(type) {
 	case []int64:
@@ -83,145 +109,92 @@ func (d *int64DictDecoder) decodeInt64(dst []int64) error {
 	return nil
 }
 
+// int64DeltaBinaryPackedDecoder reads the DELTA_BINARY_PACKED encoding for
+// INT64 columns. The block/mini-block state machine itself lives in
+// deltaBinaryPackedCore, shared with int32DeltaBinaryPackedDecoder.
 type int64DeltaBinaryPackedDecoder struct {
-	data []byte
-
-	blockSize     int32
-	miniBlocks    int32
-	miniBlockSize int32
-	numValues     int32
-
-	minDelta        int64
-	miniBlockWidths []byte
-
-	pos             int
-	i               int
-	value           int64
-	miniBlock       int
-	miniBlockWidth  int
-	unpacker        unpack8int64Func
-	miniBlockPos    int
-	miniBlockValues [8]int64
+	deltaBinaryPackedCore
 }
 
 func (d *int64DeltaBinaryPackedDecoder) init(data []byte) error {
-	d.data = data
-
-	d.pos = 0
-	d.i = 0
-
-	if err := d.readPageHeader(); err != nil {
-		return err
-	}
-	if err := d.readBlockHeader(); err != nil {
-		return err
-	}
+	d.maxWidth = 64
+	return d.deltaBinaryPackedCore.init(data)
+}
 
-	return nil
+// initReader is the streaming counterpart of init: it consumes the page
+// from r instead of requiring the whole (decompressed) page in memory,
+// reading at most size bytes.
+func (d *int64DeltaBinaryPackedDecoder) initReader(r io.Reader, size int) error {
+	d.maxWidth = 64
+	return d.deltaBinaryPackedCore.initReader(r, size)
 }
 
 func (d *int64DeltaBinaryPackedDecoder) decode(dst interface{}) error {
 	return decodeInt64(d, dst)
 }
 
-// page-header := <block size in values> <number of miniblocks in a block> <total value count> <first value>
-func (d *int64DeltaBinaryPackedDecoder) readPageHeader() error {
-	var n int
-
-	d.blockSize, n = varInt32(d.data[d.pos:])
-	if n <= 0 {
-		return fmt.Errorf("int64/delta: failed to read block size")
-	}
-	d.pos += n
+// int64DeltaBinaryPackedEncoder writes pages in the exact format
+// int64DeltaBinaryPackedDecoder consumes: a page header followed by one or
+// more blocks of deltaBlockSize values, each split into deltaMiniBlocks
+// mini-blocks that are bit-packed at their own minimal width.
+type int64DeltaBinaryPackedEncoder struct {
+	blockSize     int32
+	miniBlocks    int32
+	miniBlockSize int32
+}
 
-	d.miniBlocks, n = varInt32(d.data[d.pos:])
-	if n <= 0 {
-		return fmt.Errorf("int64/delta: failed to read number of mini blocks")
+func newInt64DeltaBinaryPackedEncoder() *int64DeltaBinaryPackedEncoder {
+	return &int64DeltaBinaryPackedEncoder{
+		blockSize:     deltaBlockSize,
+		miniBlocks:    deltaMiniBlocks,
+		miniBlockSize: deltaMiniBlockSize,
 	}
-	// TODO: valdiate d.miniBlocks
-	// TODO: do not allocate if not necessary
-	d.miniBlockWidths = make([]byte, d.miniBlocks, d.miniBlocks)
-	d.pos += n
+}
 
-	d.miniBlockSize = d.blockSize / d.miniBlocks // TODO: rounding
+func (e *int64DeltaBinaryPackedEncoder) encode(src interface{}) ([]byte, error) {
+	return encodeInt64(e, src)
+}
 
-	d.numValues, n = varInt32(d.data[d.pos:])
-	if n <= 0 {
-		return fmt.Errorf("int64/delta: failed to read total value count")
+// page-header := <block size in values> <number of miniblocks in a block> <total value count> <first value>
+func (e *int64DeltaBinaryPackedEncoder) encodeInt64(values []int64) ([]byte, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("int64/delta: no values to encode")
 	}
-	d.pos += n
 
-	d.value, n = zigZagVarInt64(d.data[d.pos:])
-	if n <= 0 {
-		return fmt.Errorf("delta: failed to read first value")
+	buf := appendVarInt32(nil, e.blockSize)
+	buf = appendVarInt32(buf, e.miniBlocks)
+	buf = appendVarInt32(buf, int32(len(values)))
+	buf = appendZigZagVarInt64(buf, values[0])
+
+	deltas := make([]int64, len(values)-1)
+	for i := 1; i < len(values); i++ {
+		deltas[i-1] = values[i] - values[i-1]
 	}
-	d.pos += n
 
-	return nil
+	return encodeDeltaBlocks(buf, deltas, e.blockSize, e.miniBlocks, e.miniBlockSize), nil
 }
 
-// block := <min delta> <list of bitwidths of miniblocks> <miniblocks>
-// min delta : zig-zag var int encoded
-// bitWidthsOfMiniBlock : 1 byte little endian
-func (d *int64DeltaBinaryPackedDecoder) readBlockHeader() error {
-	var n int
-
-	d.minDelta, n = zigZagVarInt64(d.data[d.pos:])
-	if n <= 0 {
-		return fmt.Errorf("int64/delta: failed to read min delta")
-	}
-	d.pos += n
-
-	n = copy(d.miniBlockWidths, d.data[d.pos:])
-	// TODO: validate <= 32
-	if n != len(d.miniBlockWidths) {
-		return fmt.Errorf("int64/delta: failed to read all bitwidths of miniblocks")
-	}
-	d.pos += n
+// int64ByteStreamSplitDecoder reads the BYTE_STREAM_SPLIT encoding for
+// INT64 columns.
+type int64ByteStreamSplitDecoder struct {
+	byteStreamSplitCore
+}
 
-	d.miniBlock = 0
+func (d *int64ByteStreamSplitDecoder) init(data []byte, numValues int32) error {
+	return d.byteStreamSplitCore.init(data, 8, numValues)
+}
 
-	return nil
+func (d *int64ByteStreamSplitDecoder) decode(dst interface{}) error {
+	return decodeInt64(d, dst)
 }
 
-func (d *int64DeltaBinaryPackedDecoder) decodeInt64(dst []int64) error {
-	n := 0
-	var err error
-	for n < len(dst) && d.i < int(d.numValues) {
-		if d.i%8 == 0 {
-			if d.i%int(d.miniBlockSize) == 0 {
-				if d.miniBlock >= int(d.miniBlocks) {
-					err = d.readBlockHeader()
-					if err != nil {
-						return err
-					}
-				}
-
-				d.miniBlockWidth = int(d.miniBlockWidths[d.miniBlock])
-				d.unpacker = unpack8Int64FuncByWidth[d.miniBlockWidth]
-				d.miniBlockPos = 0
-				d.miniBlock++
-			}
-			w := int(d.miniBlockWidth)
-			if d.pos+w > len(d.data) {
-				return fmt.Errorf("int64/delta: not enough data")
-			}
-			d.miniBlockValues = d.unpacker(d.data[d.pos : d.pos+w]) // TODO: validate w
-			d.miniBlockPos += w
-			d.pos += w
-			if d.i+8 >= int(d.numValues) {
-				d.pos += int(d.miniBlockSize)/8*w - d.miniBlockPos
-			}
+func (d *int64ByteStreamSplitDecoder) decodeInt64(dst []int64) error {
+	var scratch [8]byte
+	for i := range dst {
+		if err := d.next(scratch[:]); err != nil {
+			return err
 		}
-		dst[n] = d.value
-		d.value += d.miniBlockValues[d.i%8] + d.minDelta
-		d.i++
-		n++
-
-	}
-	if n == 0 {
-		return fmt.Errorf("int64/delta: no more data")
+		dst[i] = int64(binary.LittleEndian.Uint64(scratch[:]))
 	}
-
 	return nil
 }
@@ -0,0 +1,63 @@
+package parquet
+
+import "io"
+
+// readVarInt32 reads a varint in the same unsigned LEB128 format varInt32
+// decodes, one byte at a time from r.
+func readVarInt32(r io.ByteReader) (int32, error) {
+	var result uint32
+	var shift uint
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		result |= uint32(b&0x7f) << shift
+		if b < 0x80 {
+			break
+		}
+		shift += 7
+	}
+	return int32(result), nil
+}
+
+// readZigZagVarInt64 reads a zig-zag encoded varint in the same format
+// zigZagVarInt64 decodes, one byte at a time from r.
+func readZigZagVarInt64(r io.ByteReader) (int64, error) {
+	var result uint64
+	var shift uint
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		result |= uint64(b&0x7f) << shift
+		if b < 0x80 {
+			break
+		}
+		shift += 7
+	}
+	return int64(result>>1) ^ -int64(result&1), nil
+}
+
+// appendVarInt32 appends v to buf using the same unsigned LEB128 varint
+// format that varInt32 decodes.
+func appendVarInt32(buf []byte, v int32) []byte {
+	u := uint32(v)
+	for u >= 0x80 {
+		buf = append(buf, byte(u)|0x80)
+		u >>= 7
+	}
+	return append(buf, byte(u))
+}
+
+// appendZigZagVarInt64 zig-zag encodes v and appends it to buf using the
+// same varint format that zigZagVarInt64 decodes.
+func appendZigZagVarInt64(buf []byte, v int64) []byte {
+	u := uint64((v << 1) ^ (v >> 63))
+	for u >= 0x80 {
+		buf = append(buf, byte(u)|0x80)
+		u >>= 7
+	}
+	return append(buf, byte(u))
+}